@@ -0,0 +1,162 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// NeuronFunc runs one step of a Graph, reading and writing shared Memory.
+type NeuronFunc func(ctx context.Context, mem *Memory) error
+
+// CastFunc inspects Memory after a Neuron has run and returns the names of the
+// CastGroups ("links") that should fire next, e.g. []string{"continue"} or
+// []string{"end"}. Returning multiple names fires multiple links concurrently.
+type CastFunc func(mem *Memory) []string
+
+// Neuron is one node of a Graph: a unit of work (Run) plus the logic that
+// decides which outgoing Links fire next (Cast).
+type Neuron struct {
+	Name string
+	Run  NeuronFunc
+	Cast CastFunc
+}
+
+// Link connects two neurons under a named CastGroup. A Link with an empty To
+// is a terminal "end" link: when it fires, the Graph's Run call returns.
+type Link struct {
+	From string
+	Name string
+	To   string
+}
+
+// Graph is a set of Neurons wired together by Links. Build one with NewGraph,
+// AddNeuron and AddLink, then call Run to execute it starting from a neuron.
+type Graph struct {
+	neurons map[string]*Neuron
+	links   []Link
+}
+
+// NewGraph returns an empty Graph.
+func NewGraph() *Graph {
+	return &Graph{neurons: make(map[string]*Neuron)}
+}
+
+// AddNeuron registers a neuron under its own Name, returning the Graph so
+// calls can be chained.
+func (g *Graph) AddNeuron(n *Neuron) *Graph {
+	g.neurons[n.Name] = n
+	return g
+}
+
+// AddLink wires from's CastGroup named name to the neuron named to. Pass an
+// empty to make this a terminal end-link. Returns the Graph so calls can be
+// chained.
+func (g *Graph) AddLink(from, name, to string) *Graph {
+	g.links = append(g.links, Link{From: from, Name: name, To: to})
+	return g
+}
+
+// Run starts execution at the neuron named start and blocks until a terminal
+// end-link fires or a neuron returns an error. Neurons run concurrently
+// whenever a Cast fans out to more than one outgoing Link. A neuron with
+// multiple incoming Links is gated behind a join/barrier: it only actually
+// runs once every distinct neuron that links into it has fired, so it sees
+// Memory after all of its producers completed rather than once per arrival.
+func (g *Graph) Run(ctx context.Context, start string, mem *Memory) error {
+	incoming := g.incomingSources()
+
+	var (
+		stateMu sync.Mutex
+		arrived = make(map[string]map[string]bool)
+	)
+
+	// ready records that from has fired a link into target, and reports
+	// whether target has now heard from every distinct neuron that links into
+	// it (its join is satisfied). The arrival set is cleared once satisfied
+	// so a later cycle back through target gates again from scratch.
+	ready := func(target, from string) bool {
+		stateMu.Lock()
+		defer stateMu.Unlock()
+
+		if arrived[target] == nil {
+			arrived[target] = make(map[string]bool)
+		}
+		arrived[target][from] = true
+		if len(arrived[target]) < len(incoming[target]) {
+			return false
+		}
+		delete(arrived, target)
+		return true
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan error, 1)
+	var once sync.Once
+	finish := func(err error) { once.Do(func() { done <- err }) }
+
+	var fire func(name string)
+	fire = func(name string) {
+		defer wg.Done()
+
+		neuron, ok := g.neurons[name]
+		if !ok {
+			finish(fmt.Errorf("agent: unknown neuron %q", name))
+			return
+		}
+
+		if err := neuron.Run(ctx, mem); err != nil {
+			finish(fmt.Errorf("agent: neuron %q: %w", name, err))
+			return
+		}
+
+		for _, group := range neuron.Cast(mem) {
+			matched := false
+			for _, link := range g.links {
+				if link.From != name || link.Name != group {
+					continue
+				}
+				matched = true
+				if link.To == "" {
+					finish(nil)
+					continue
+				}
+				if !ready(link.To, link.From) {
+					continue
+				}
+				wg.Add(1)
+				go fire(link.To)
+			}
+			if !matched {
+				finish(fmt.Errorf("agent: neuron %q has no link named %q", name, group))
+			}
+		}
+	}
+
+	wg.Add(1)
+	go fire(start)
+
+	go func() {
+		wg.Wait()
+		finish(nil)
+	}()
+
+	return <-done
+}
+
+// incomingSources maps each neuron name to the set of distinct neurons with a
+// non-terminal Link into it, i.e. the producers Run's join/barrier waits on
+// before letting that neuron fire.
+func (g *Graph) incomingSources() map[string]map[string]bool {
+	sources := make(map[string]map[string]bool)
+	for _, link := range g.links {
+		if link.To == "" {
+			continue
+		}
+		if sources[link.To] == nil {
+			sources[link.To] = make(map[string]bool)
+		}
+		sources[link.To][link.From] = true
+	}
+	return sources
+}
@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+// TestGraphRunJoinsMultipleIncomingLinks verifies the join/barrier behavior:
+// a neuron with links from two distinct producers runs exactly once, after
+// both producers have completed, rather than once per arriving link.
+func TestGraphRunJoinsMultipleIncomingLinks(t *testing.T) {
+	var joinRuns int32
+
+	start := &Neuron{
+		Name: "start",
+		Run:  func(ctx context.Context, mem *Memory) error { return nil },
+		Cast: func(mem *Memory) []string { return []string{"fanout"} },
+	}
+	a := &Neuron{
+		Name: "a",
+		Run: func(ctx context.Context, mem *Memory) error {
+			mem.Set("a", true)
+			return nil
+		},
+		Cast: func(mem *Memory) []string { return []string{"toJoin"} },
+	}
+	b := &Neuron{
+		Name: "b",
+		Run: func(ctx context.Context, mem *Memory) error {
+			mem.Set("b", true)
+			return nil
+		},
+		Cast: func(mem *Memory) []string { return []string{"toJoin"} },
+	}
+	join := &Neuron{
+		Name: "join",
+		Run: func(ctx context.Context, mem *Memory) error {
+			atomic.AddInt32(&joinRuns, 1)
+			aVal, _ := mem.Get("a")
+			bVal, _ := mem.Get("b")
+			if aVal != true || bVal != true {
+				t.Errorf("join ran before both producers completed: a=%v b=%v", aVal, bVal)
+			}
+			return nil
+		},
+		Cast: func(mem *Memory) []string { return []string{"end"} },
+	}
+
+	g := NewGraph().
+		AddNeuron(start).
+		AddNeuron(a).
+		AddNeuron(b).
+		AddNeuron(join).
+		AddLink("start", "fanout", "a").
+		AddLink("start", "fanout", "b").
+		AddLink("a", "toJoin", "join").
+		AddLink("b", "toJoin", "join").
+		AddLink("join", "end", "")
+
+	if err := g.Run(context.Background(), "start", NewMemory(nil)); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&joinRuns); got != 1 {
+		t.Errorf("join ran %d times, want exactly 1", got)
+	}
+}
+
+// TestGraphRunCycleReJoinsEachRound confirms the join/barrier resets after it
+// fires, so a neuron reached by a loop (e.g. the llm<->action pattern in
+// main.go) keeps running on every pass rather than only once.
+func TestGraphRunCycleReJoinsEachRound(t *testing.T) {
+	llmRuns := 0
+
+	llm := &Neuron{
+		Name: "llm",
+		Run: func(ctx context.Context, mem *Memory) error {
+			llmRuns++
+			return nil
+		},
+		Cast: func(mem *Memory) []string {
+			if llmRuns >= 3 {
+				return []string{"end"}
+			}
+			return []string{"continue"}
+		},
+	}
+	action := &Neuron{
+		Name: "action",
+		Run:  func(ctx context.Context, mem *Memory) error { return nil },
+		Cast: func(mem *Memory) []string { return []string{"continue"} },
+	}
+
+	g := NewGraph().
+		AddNeuron(llm).
+		AddNeuron(action).
+		AddLink("llm", "continue", "action").
+		AddLink("action", "continue", "llm").
+		AddLink("llm", "end", "")
+
+	if err := g.Run(context.Background(), "llm", NewMemory(nil)); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if llmRuns != 3 {
+		t.Errorf("llm ran %d times, want 3", llmRuns)
+	}
+}
+
+func TestGraphRunUnknownCastGroupErrors(t *testing.T) {
+	n := &Neuron{
+		Name: "solo",
+		Run:  func(ctx context.Context, mem *Memory) error { return nil },
+		Cast: func(mem *Memory) []string { return []string{"nowhere"} },
+	}
+	g := NewGraph().AddNeuron(n)
+
+	if err := g.Run(context.Background(), "solo", NewMemory(nil)); err == nil {
+		t.Fatal("expected an error for a Cast group with no matching link, got nil")
+	}
+}
@@ -0,0 +1,38 @@
+// Package agent provides a small neuron/link graph runtime for composing LLM
+// and tool-calling steps into richer flows (parallel tools, retry branches,
+// human-in-the-loop) without hard-coding a single sequential loop.
+package agent
+
+import "sync"
+
+// Memory is the mutable state shared between every Neuron in a Graph run. It is
+// safe for concurrent use, since neurons with satisfied inputs may run at the
+// same time.
+type Memory struct {
+	mu   sync.RWMutex
+	data map[string]any
+}
+
+// NewMemory returns an empty Memory, optionally seeded with initial values.
+func NewMemory(seed map[string]any) *Memory {
+	data := make(map[string]any, len(seed))
+	for k, v := range seed {
+		data[k] = v
+	}
+	return &Memory{data: data}
+}
+
+// Get returns the value stored under key, and whether it was present.
+func (m *Memory) Get(key string) (any, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.data[key]
+	return v, ok
+}
+
+// Set stores val under key, overwriting any previous value.
+func (m *Memory) Set(key string, val any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = val
+}
@@ -2,17 +2,27 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"sort"
+	"strings"
 
+	agent "function-calling/agent"
 	tools "function-calling/tools"
 
 	godotenv "github.com/joho/godotenv"
 	openai "github.com/sashabaranov/go-openai"
 )
 
+// maxLoops bounds how many times the llm neuron may hand off to the action
+// neuron before the graph is forced to end, guarding against a model that
+// keeps requesting tools forever.
+const maxLoops = 5
+
 // ChatMessages represents a collection of chat messages for OpenAI API
 type ChatMessages []openai.ChatCompletionMessage
 
@@ -120,6 +130,186 @@ func ChatWithTools(message []openai.ChatCompletionMessage, tools []openai.Tool)
 	return rsp.Choices[0].Message
 }
 
+// StreamEventType identifies the kind of event a ChatStream channel emits.
+type StreamEventType int
+
+const (
+	TextDelta StreamEventType = iota
+	ToolCallDelta
+	Done
+	Err
+)
+
+// StreamEvent is one update from ChatStream. Text is populated for TextDelta.
+// ToolCalls holds the accumulated-so-far tool calls for ToolCallDelta, and the
+// fully-formed final list for Done. Message is only populated for Done, and
+// Err only for Err.
+type StreamEvent struct {
+	Type      StreamEventType
+	Text      string
+	ToolCalls []openai.ToolCall
+	Message   openai.ChatCompletionMessage
+	Err       error
+}
+
+// toolCallBuilder accumulates one streamed tool call's fragments. OpenAI-
+// compatible streaming splits a tool call's id, name and arguments across many
+// deltas that share the same Index, so each field is only overwritten once a
+// delta actually carries it, and Arguments fragments are concatenated.
+type toolCallBuilder struct {
+	id        string
+	name      string
+	arguments strings.Builder
+}
+
+// toolCallAccumulator collects streamed tool call deltas by their Index and
+// can produce a fully-formed, index-ordered []openai.ToolCall at any point.
+type toolCallAccumulator struct {
+	order []int
+	byIdx map[int]*toolCallBuilder
+}
+
+func newToolCallAccumulator() *toolCallAccumulator {
+	return &toolCallAccumulator{byIdx: make(map[int]*toolCallBuilder)}
+}
+
+func (a *toolCallAccumulator) add(deltas []openai.ToolCall) {
+	for _, d := range deltas {
+		idx := 0
+		if d.Index != nil {
+			idx = *d.Index
+		}
+
+		b, ok := a.byIdx[idx]
+		if !ok {
+			b = &toolCallBuilder{}
+			a.byIdx[idx] = b
+			a.order = append(a.order, idx)
+		}
+
+		if d.ID != "" {
+			b.id = d.ID
+		}
+		if d.Function.Name != "" {
+			b.name = d.Function.Name
+		}
+		if d.Function.Arguments != "" {
+			b.arguments.WriteString(d.Function.Arguments)
+		}
+	}
+}
+
+func (a *toolCallAccumulator) snapshot() []openai.ToolCall {
+	if len(a.order) == 0 {
+		return nil
+	}
+
+	sorted := append([]int(nil), a.order...)
+	sort.Ints(sorted)
+
+	calls := make([]openai.ToolCall, 0, len(sorted))
+	for _, idx := range sorted {
+		b := a.byIdx[idx]
+		calls = append(calls, openai.ToolCall{
+			ID:   b.id,
+			Type: openai.ToolTypeFunction,
+			Function: openai.FunctionCall{
+				Name:      b.name,
+				Arguments: b.arguments.String(),
+			},
+		})
+	}
+	return calls
+}
+
+// ChatStream is the streaming counterpart to Chat/ChatWithTools: it sends the
+// message history to the model and returns a channel of StreamEvents instead
+// of waiting for the full response. The channel is closed after it emits a
+// Done or Err event.
+func ChatStream(ctx context.Context, message []openai.ChatCompletionMessage, toolsList []openai.Tool) <-chan StreamEvent {
+	events := make(chan StreamEvent)
+
+	go func() {
+		defer close(events)
+
+		client := NewOpenAiClient()
+		req := openai.ChatCompletionRequest{
+			Model:    os.Getenv("DASH_SCOPE_MODEL"),
+			Messages: message,
+			Stream:   true,
+		}
+		if len(toolsList) > 0 {
+			req.Tools = toolsList
+			req.ToolChoice = "auto"
+		}
+
+		stream, err := client.CreateChatCompletionStream(ctx, req)
+		if err != nil {
+			events <- StreamEvent{Type: Err, Err: fmt.Errorf("error creating chat stream: %w", err)}
+			return
+		}
+		defer stream.Close()
+
+		var content strings.Builder
+		acc := newToolCallAccumulator()
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				events <- StreamEvent{Type: Err, Err: fmt.Errorf("error receiving stream chunk: %w", err)}
+				return
+			}
+			if len(resp.Choices) == 0 {
+				continue
+			}
+
+			delta := resp.Choices[0].Delta
+
+			if delta.Content != "" {
+				content.WriteString(delta.Content)
+				events <- StreamEvent{Type: TextDelta, Text: delta.Content}
+			}
+
+			if len(delta.ToolCalls) > 0 {
+				acc.add(delta.ToolCalls)
+				events <- StreamEvent{Type: ToolCallDelta, ToolCalls: acc.snapshot()}
+			}
+		}
+
+		toolCalls := acc.snapshot()
+		msg := openai.ChatCompletionMessage{
+			Role:      openai.ChatMessageRoleAssistant,
+			Content:   content.String(),
+			ToolCalls: toolCalls,
+		}
+		events <- StreamEvent{Type: Done, ToolCalls: toolCalls, Message: msg}
+	}()
+
+	return events
+}
+
+// streamChat drains a ChatStream channel, printing each text delta as it
+// arrives so the user sees partial output before the final answer, and
+// returns the fully-formed message once the stream reports Done.
+func streamChat(ctx context.Context, message []openai.ChatCompletionMessage, toolsList []openai.Tool) openai.ChatCompletionMessage {
+	for event := range ChatStream(ctx, message, toolsList) {
+		switch event.Type {
+		case TextDelta:
+			fmt.Print(event.Text)
+		case Done:
+			fmt.Println()
+			return event.Message
+		case Err:
+			log.Println(event.Err)
+			return openai.ChatCompletionMessage{}
+		}
+	}
+	return openai.ChatCompletionMessage{}
+}
+
 // printDebugInfo prints debug information about the message store
 func printDebugInfo() {
 	fmt.Println("# Message Store Debug:")
@@ -130,7 +320,100 @@ func printDebugInfo() {
 	}
 }
 
+// newToolRegistry builds the tools.Registry used by the action neuron. Adding
+// a new tool only requires one more tools.Register call here.
+func newToolRegistry() *tools.Registry {
+	registry := tools.NewRegistry()
+
+	tools.Register(registry, tools.GetCurrentWeatherToolDefine, func(ctx context.Context, args tools.CurrentWeatherParams) (string, error) {
+		return tools.GetCurrentWeather(args)
+	})
+	tools.Register(registry, tools.GetWeatherForecastToolDefine, func(ctx context.Context, args tools.ForecastWeatherParams) (string, error) {
+		return tools.GetWeatherForecast(args)
+	})
+	tools.Register(registry, tools.AssessOutdoorConditionsToolDefine, func(ctx context.Context, args tools.AssessOutdoorConditionsParams) (string, error) {
+		return tools.AssessOutdoorConditions(args)
+	})
+	tools.Register(registry, tools.GeocodeToolDefine, func(ctx context.Context, args tools.GeocodeParams) (string, error) {
+		return tools.GeocodeLocation(args)
+	})
+
+	return registry
+}
+
+// buildGraph wires the llm -> action -> llm agent graph: the llm neuron calls
+// the model and casts to "continue" when it selects at least one tool call
+// (and we're still under maxLoops) or "end" otherwise; the action neuron
+// dispatches every selected tool call concurrently through registry and
+// always casts back to "continue", handing control back to the llm.
+func buildGraph(toolsList []openai.Tool, registry *tools.Registry, stream bool) *agent.Graph {
+	llm := &agent.Neuron{
+		Name: "llm",
+		Run: func(ctx context.Context, mem *agent.Memory) error {
+			loopCount, _ := mem.Get("loopCount")
+			fmt.Printf("-------------- The %d round response ------------------\n", loopCount)
+			printDebugInfo()
+
+			var response openai.ChatCompletionMessage
+			if stream {
+				response = streamChat(ctx, MessageStore.GetMessages(), toolsList)
+			} else {
+				response = ChatWithTools(MessageStore.GetMessages(), toolsList)
+			}
+			mem.Set("response", response)
+			return nil
+		},
+		Cast: func(mem *agent.Memory) []string {
+			response, _ := mem.Get("response")
+			loopCount, _ := mem.Get("loopCount")
+
+			msg := response.(openai.ChatCompletionMessage)
+			if msg.ToolCalls == nil || loopCount.(int) >= maxLoops {
+				fmt.Println("Final response from LLM: ", msg.Content)
+				return []string{"end"}
+			}
+
+			fmt.Println("Response from LLM: ", msg.Content)
+			fmt.Println("Selected Tool by LLM: ", msg.ToolCalls)
+			return []string{"continue"}
+		},
+	}
+
+	action := &agent.Neuron{
+		Name: "action",
+		Run: func(ctx context.Context, mem *agent.Memory) error {
+			response, _ := mem.Get("response")
+			msg := response.(openai.ChatCompletionMessage)
+
+			MessageStore.AppendMessage(RoleAssistant, msg.Content, msg.ToolCalls)
+
+			toolMessages := registry.Dispatch(ctx, msg.ToolCalls)
+			for _, toolMsg := range toolMessages {
+				fmt.Printf("Result from tool %s: \n%s\n", toolMsg.Name, toolMsg.Content)
+				MessageStore.AddTool(toolMsg.Content, toolMsg.Name, toolMsg.ToolCallID)
+			}
+
+			loopCount, _ := mem.Get("loopCount")
+			mem.Set("loopCount", loopCount.(int)+1)
+			return nil
+		},
+		Cast: func(mem *agent.Memory) []string {
+			return []string{"continue"}
+		},
+	}
+
+	return agent.NewGraph().
+		AddNeuron(llm).
+		AddNeuron(action).
+		AddLink("llm", "continue", "action").
+		AddLink("action", "continue", "llm").
+		AddLink("llm", "end", "")
+}
+
 func main() {
+	streamFlag := flag.Bool("stream", false, "stream partial LLM output as it arrives instead of waiting for the full response")
+	flag.Parse()
+
 	// Example with chat history
 	// MessageStore.AppendMessage(RoleSystem, "你是一名 AIOps 专家，请尽可能地帮我回答与 AIOps 相关的问题。")
 	// MessageStore.AppendMessage(RoleUser, "AIOps 是什么？")
@@ -141,57 +424,21 @@ func main() {
 	// fmt.Println(response.Content)
 
 	toolsList := make([]openai.Tool, 0)
-	toolsList = append(toolsList, tools.WeatherToolDefine)
+	toolsList = append(toolsList,
+		tools.GetCurrentWeatherToolDefine,
+		tools.GetWeatherForecastToolDefine,
+		tools.AssessOutdoorConditionsToolDefine,
+		tools.GeocodeToolDefine,
+	)
 
 	// MessageStore.AppendMessage(RoleSystem, "You are a weather expert, please help me answer questions about weather.", nil)
 	prompt := "What's the weather in Shenzhen? Is it suitable for outdoor activities?"
 	// prompt := "帮我查询一下深圳当前的天气情况，今天适合出去游玩吗？ Let's think step by step."
 	MessageStore.AppendMessage(RoleUser, prompt, nil)
 
-	response := ChatWithTools(MessageStore.GetMessages(), toolsList)
-	toolCalls := response.ToolCalls
-
-	maxLoops := 5
-	loopCount := 0
-
-	for {
-		fmt.Printf("-------------- The %d round response ------------------\n", loopCount)
-		printDebugInfo()
-
-		if toolCalls == nil || loopCount >= maxLoops {
-			fmt.Println("Final response from LLM: ", response.Content)
-			break
-		} else {
-			fmt.Println("Response from LLM: ", response.Content)
-			fmt.Println("Selected Tool by LLM: ", toolCalls)
-			fmt.Println("Tool Call Arguments: ", toolCalls[0].Function.Arguments)
-
-			var result string
-			var args tools.WeatherParams
-			err := json.Unmarshal([]byte(toolCalls[0].Function.Arguments), &args)
-			if err != nil {
-				log.Fatalln("Failed to unmarshal tool call arguments: ", err.Error())
-				return
-			}
-
-			// Call the tool
-			if toolCalls[0].Function.Name == tools.WeatherToolDefine.Function.Name {
-				result, err = tools.GetWeather(args)
-				if err != nil {
-					log.Fatalln("Failed to get weather: ", err.Error())
-					return
-				}
-			}
-
-			fmt.Println("Result from tool: \n", result)
-
-			// Append the response from LLM and the tool call result to the message history
-			MessageStore.AppendMessage(RoleAssistant, response.Content, toolCalls)
-			MessageStore.AddTool(string(result), toolCalls[0].Function.Name, toolCalls[0].ID)
-
-			response = ChatWithTools(MessageStore.GetMessages(), toolsList)
-			toolCalls = response.ToolCalls
-			loopCount++
-		}
+	registry := newToolRegistry()
+	mem := agent.NewMemory(map[string]any{"loopCount": 0})
+	if err := buildGraph(toolsList, registry, *streamFlag).Run(context.Background(), "llm", mem); err != nil {
+		log.Fatalln("Agent graph failed: ", err.Error())
 	}
 }
@@ -0,0 +1,215 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// GeocodeToolDefine defines the OpenAI tool for resolving a free-text place name
+// to coordinates, so the LLM does not have to guess latitude/longitude.
+var GeocodeToolDefine = openai.Tool{
+	Type: "function",
+	Function: &openai.FunctionDefinition{
+		Name: "GeocodeLocation",
+		Description: `
+		Use this tool to resolve a place name to coordinates before calling
+		GetCurrentWeather, GetWeatherForecast, or AssessOutdoorConditions.
+		Example:
+			"What's the weather in Shenzhen?"
+		Then Action Input is: {"query": "Shenzhen"}
+
+		The query can include a country or region for disambiguation:
+		{"query": "Munich, DE"}
+		`,
+		Parameters: `{
+			"type": "object",
+			"properties": {
+				"query": {
+					"type": "string",
+					"description": "Free-text place name to resolve, e.g. 'Shenzhen' or 'Munich, DE'"
+				}
+			},
+			"required": ["query"]
+		}`,
+	},
+}
+
+// GeocodeParams contains parameters for the GeocodeLocation tool.
+type GeocodeParams struct {
+	Query string `json:"query"`
+}
+
+// GeocodeResult is the resolved location returned by GeocodeLocation.
+type GeocodeResult struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Timezone  string  `json:"timezone,omitempty"`
+	Country   string  `json:"country,omitempty"`
+	Admin1    string  `json:"admin1,omitempty"`
+}
+
+// nominatimUserAgent identifies this application to Nominatim, as required by
+// its usage policy (https://operations.osmfoundation.org/policies/nominatim/).
+const nominatimUserAgent = "function-calling-weather-tool/1.0 (https://github.com/JoneyXiao/function-calling)"
+
+var (
+	nominatimSearchURL  = "https://nominatim.openstreetmap.org/search"
+	openMeteoGeocodeURL = "https://geocoding-api.open-meteo.com/v1/search"
+
+	// nominatimMinInterval throttles requests to respect Nominatim's documented
+	// rate limit of at most one request per second.
+	nominatimMinInterval = time.Second
+	nominatimMu          sync.Mutex
+	nominatimLastRequest time.Time
+)
+
+// geocodeCache is a small on-disk LRU so repeated conversations don't hammer
+// Nominatim for the same query.
+var geocodeCache = newGeocodeCache(128, geocodeCachePath())
+
+func geocodeCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "function-calling", "geocode_cache.json")
+}
+
+// normalizeGeocodeQuery collapses whitespace and case so equivalent queries
+// ("Shenzhen" vs "  shenzhen ") share one cache entry.
+func normalizeGeocodeQuery(query string) string {
+	return strings.ToLower(strings.TrimSpace(query))
+}
+
+// GeocodeLocation resolves a free-text place name to coordinates, trying
+// Nominatim first and falling back to Open-Meteo's geocoding API.
+func GeocodeLocation(params GeocodeParams) (string, error) {
+	if strings.TrimSpace(params.Query) == "" {
+		return "", fmt.Errorf("query must not be empty")
+	}
+
+	key := normalizeGeocodeQuery(params.Query)
+	if cached, ok := geocodeCache.Get(key); ok {
+		return marshalGeocodeResult(cached)
+	}
+
+	ctx := context.Background()
+
+	result, err := geocodeWithNominatim(ctx, params.Query)
+	if err != nil {
+		result, err = geocodeWithOpenMeteo(ctx, params.Query)
+		if err != nil {
+			return "", fmt.Errorf("failed to geocode %q: %w", params.Query, err)
+		}
+	}
+
+	geocodeCache.Put(key, result)
+	return marshalGeocodeResult(result)
+}
+
+func marshalGeocodeResult(result GeocodeResult) (string, error) {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling geocode result: %w", err)
+	}
+	return string(body), nil
+}
+
+type nominatimEntry struct {
+	Lat         string `json:"lat"`
+	Lon         string `json:"lon"`
+	DisplayName string `json:"display_name"`
+	Address     struct {
+		CountryCode string `json:"country_code"`
+		State       string `json:"state"`
+	} `json:"address"`
+}
+
+func geocodeWithNominatim(ctx context.Context, query string) (GeocodeResult, error) {
+	waitForNominatimRateLimit()
+
+	q := url.Values{}
+	q.Add("q", query)
+	q.Add("format", "jsonv2")
+	q.Add("addressdetails", "1")
+	q.Add("limit", "1")
+
+	var entries []nominatimEntry
+	headers := map[string]string{"User-Agent": nominatimUserAgent}
+	if err := getJSON(ctx, fmt.Sprintf("%s?%s", nominatimSearchURL, q.Encode()), headers, &entries); err != nil {
+		return GeocodeResult{}, fmt.Errorf("nominatim: %w", err)
+	}
+	if len(entries) == 0 {
+		return GeocodeResult{}, fmt.Errorf("nominatim: no results for %q", query)
+	}
+
+	entry := entries[0]
+	var lat, lon float64
+	if _, err := fmt.Sscanf(entry.Lat, "%f", &lat); err != nil {
+		return GeocodeResult{}, fmt.Errorf("nominatim: invalid latitude %q", entry.Lat)
+	}
+	if _, err := fmt.Sscanf(entry.Lon, "%f", &lon); err != nil {
+		return GeocodeResult{}, fmt.Errorf("nominatim: invalid longitude %q", entry.Lon)
+	}
+
+	return GeocodeResult{
+		Latitude:  lat,
+		Longitude: lon,
+		Country:   strings.ToUpper(entry.Address.CountryCode),
+		Admin1:    entry.Address.State,
+	}, nil
+}
+
+// waitForNominatimRateLimit blocks until at least nominatimMinInterval has
+// elapsed since the last Nominatim request, per its usage policy.
+func waitForNominatimRateLimit() {
+	nominatimMu.Lock()
+	defer nominatimMu.Unlock()
+
+	if elapsed := time.Since(nominatimLastRequest); elapsed < nominatimMinInterval {
+		time.Sleep(nominatimMinInterval - elapsed)
+	}
+	nominatimLastRequest = time.Now()
+}
+
+type openMeteoGeocodeResponse struct {
+	Results []struct {
+		Latitude    float64 `json:"latitude"`
+		Longitude   float64 `json:"longitude"`
+		Timezone    string  `json:"timezone"`
+		CountryCode string  `json:"country_code"`
+		Admin1      string  `json:"admin1"`
+	} `json:"results"`
+}
+
+func geocodeWithOpenMeteo(ctx context.Context, query string) (GeocodeResult, error) {
+	q := url.Values{}
+	q.Add("name", query)
+	q.Add("count", "1")
+
+	var data openMeteoGeocodeResponse
+	if err := getJSON(ctx, fmt.Sprintf("%s?%s", openMeteoGeocodeURL, q.Encode()), nil, &data); err != nil {
+		return GeocodeResult{}, fmt.Errorf("open-meteo geocoding: %w", err)
+	}
+	if len(data.Results) == 0 {
+		return GeocodeResult{}, fmt.Errorf("open-meteo geocoding: no results for %q", query)
+	}
+
+	r := data.Results[0]
+	return GeocodeResult{
+		Latitude:  r.Latitude,
+		Longitude: r.Longitude,
+		Timezone:  r.Timezone,
+		Country:   r.CountryCode,
+		Admin1:    r.Admin1,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"container/list"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// geocodeCacheEntry pairs a cache key with its resolved result, so the LRU list
+// can be walked to rebuild the on-disk snapshot in recency order.
+type geocodeCacheEntry struct {
+	Key    string        `json:"key"`
+	Result GeocodeResult `json:"result"`
+}
+
+// geocodeLRU is a small, size-bounded LRU cache for geocoding results, persisted
+// to a single JSON file so it survives across runs.
+type geocodeLRU struct {
+	mu       sync.Mutex
+	capacity int
+	path     string
+	order    *list.List
+	elements map[string]*list.Element
+}
+
+func newGeocodeCache(capacity int, path string) *geocodeLRU {
+	c := &geocodeLRU{
+		capacity: capacity,
+		path:     path,
+		order:    list.New(),
+		elements: make(map[string]*list.Element),
+	}
+	c.load()
+	return c
+}
+
+func (c *geocodeLRU) Get(key string) (GeocodeResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.elements[key]
+	if !ok {
+		return GeocodeResult{}, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*geocodeCacheEntry).Result, true
+}
+
+func (c *geocodeLRU) Put(key string, result GeocodeResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.elements[key]; ok {
+		el.Value.(*geocodeCacheEntry).Result = result
+		c.order.MoveToFront(el)
+	} else {
+		el := c.order.PushFront(&geocodeCacheEntry{Key: key, Result: result})
+		c.elements[key] = el
+
+		if c.order.Len() > c.capacity {
+			oldest := c.order.Back()
+			if oldest != nil {
+				c.order.Remove(oldest)
+				delete(c.elements, oldest.Value.(*geocodeCacheEntry).Key)
+			}
+		}
+	}
+
+	c.save()
+}
+
+// load populates the cache from its on-disk snapshot, if any. A missing or
+// unreadable file is not an error; the cache simply starts empty.
+func (c *geocodeLRU) load() {
+	body, err := os.ReadFile(c.path)
+	if err != nil {
+		return
+	}
+
+	var entries []geocodeCacheEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		el := c.order.PushBack(&geocodeCacheEntry{Key: entry.Key, Result: entry.Result})
+		c.elements[entry.Key] = el
+	}
+}
+
+// save writes the current cache contents to disk in most-recently-used-first
+// order. Failures are ignored; the cache still works in-memory for this run.
+func (c *geocodeLRU) save() {
+	entries := make([]geocodeCacheEntry, 0, c.order.Len())
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		entries = append(entries, *el.Value.(*geocodeCacheEntry))
+	}
+
+	body, err := json.Marshal(entries)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path, body, 0o644)
+}
@@ -0,0 +1,172 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// AssessOutdoorConditionsToolDefine defines the OpenAI tool that scores how
+// suitable the near-term forecast is for a named outdoor activity, so small
+// models can answer "is it suitable for outdoor activities?" with a rule-based
+// score instead of guessing from the raw forecast.
+var AssessOutdoorConditionsToolDefine = openai.Tool{
+	Type: "function",
+	Function: &openai.FunctionDefinition{
+		Name: "AssessOutdoorConditions",
+		Description: `
+		Use this tool to judge whether the near-term weather at a location is
+		suitable for a named outdoor activity (e.g. "hiking", "running", "cycling",
+		"picnic", "swimming"). Returns a 0-100 suitability score and a short reason.
+		Example:
+			"Is it suitable for outdoor activities in Shenzhen today?"
+		Then Action Input is: {"latitude": 22.547, "longitude": 114.058, "activity": "picnic"}
+		`,
+		Parameters: `{
+			"type": "object",
+			"properties": {
+				"latitude": {
+					"type": "number",
+					"description": "Latitude coordinate of the location"
+				},
+				"longitude": {
+					"type": "number",
+					"description": "Longitude coordinate of the location"
+				},
+				"activity": {
+					"type": "string",
+					"description": "Outdoor activity to assess, e.g. 'hiking', 'running', 'cycling', 'picnic', 'swimming'"
+				},
+				"provider": {
+					"type": "string",
+					"description": "Weather backend to use: 'open-meteo' (default), 'openweathermap', or 'metno'"
+				}
+			},
+			"required": ["latitude", "longitude", "activity"]
+		}`,
+	},
+}
+
+// AssessOutdoorConditionsParams contains parameters for the
+// AssessOutdoorConditions tool.
+type AssessOutdoorConditionsParams struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Activity  string  `json:"activity"`
+	Provider  string  `json:"provider,omitempty"`
+}
+
+// AssessOutdoorConditionsResult is the score and rationale returned by
+// AssessOutdoorConditions, marshaled to JSON for the LLM.
+type AssessOutdoorConditionsResult struct {
+	Score  int    `json:"score"`
+	Reason string `json:"reason"`
+}
+
+// activityTemperatureBand is the comfortable temperature range for an outdoor
+// activity; temperatures outside it penalize the suitability score.
+type activityTemperatureBand struct {
+	minC, maxC float64
+}
+
+var activityTemperatureBands = map[string]activityTemperatureBand{
+	"running":  {minC: 5, maxC: 25},
+	"cycling":  {minC: 8, maxC: 30},
+	"hiking":   {minC: 0, maxC: 28},
+	"picnic":   {minC: 12, maxC: 30},
+	"swimming": {minC: 22, maxC: 35},
+}
+
+// defaultActivityTemperatureBand is used for activities not in
+// activityTemperatureBands.
+var defaultActivityTemperatureBand = activityTemperatureBand{minC: 5, maxC: 28}
+
+const (
+	maxOutdoorWindSpeedKmh    = 40.0
+	maxOutdoorPrecipitationMm = 10.0
+)
+
+// AssessOutdoorConditions fetches a short forecast and applies rule-based
+// scoring (temperature band, heavy weather, wind, precipitation) to produce a
+// 0-100 suitability score and a short explanation for the given activity.
+func AssessOutdoorConditions(params AssessOutdoorConditionsParams) (string, error) {
+	provider, err := weatherProvider(params.Provider)
+	if err != nil {
+		return "", err
+	}
+
+	forecast, err := provider.Forecast(context.Background(), params.Latitude, params.Longitude, 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to get forecast: %w", err)
+	}
+
+	point := firstForecastPoint(forecast)
+	if point == nil {
+		return "", fmt.Errorf("no forecast data available for the requested location")
+	}
+
+	band, ok := activityTemperatureBands[strings.ToLower(params.Activity)]
+	if !ok {
+		band = defaultActivityTemperatureBand
+	}
+
+	score := 100
+	var reasons []string
+
+	temp := point.TemperatureC
+	if temp == 0 && (point.TemperatureMinC != 0 || point.TemperatureMaxC != 0) {
+		temp = (point.TemperatureMinC + point.TemperatureMaxC) / 2
+	}
+	if temp < band.minC || temp > band.maxC {
+		score -= 30
+		reasons = append(reasons, fmt.Sprintf("temperature %.1f°C is outside the %.0f-%.0f°C band for %s", temp, band.minC, band.maxC, params.Activity))
+	}
+
+	if point.Severe {
+		score -= 40
+		reasons = append(reasons, fmt.Sprintf("conditions (%s) are unsafe for outdoor activity", point.Description))
+	}
+
+	if point.WindSpeedKmh > maxOutdoorWindSpeedKmh {
+		score -= 20
+		reasons = append(reasons, fmt.Sprintf("wind speed %.1f km/h exceeds %.0f km/h", point.WindSpeedKmh, maxOutdoorWindSpeedKmh))
+	}
+
+	if point.PrecipitationMm > maxOutdoorPrecipitationMm {
+		score -= 20
+		reasons = append(reasons, fmt.Sprintf("precipitation %.1f mm exceeds %.0f mm", point.PrecipitationMm, maxOutdoorPrecipitationMm))
+	}
+
+	if score < 0 {
+		score = 0
+	}
+
+	reason := "conditions look good"
+	if len(reasons) > 0 {
+		reason = strings.Join(reasons, "; ")
+	}
+
+	body, err := json.Marshal(AssessOutdoorConditionsResult{Score: score, Reason: reason})
+	if err != nil {
+		return "", fmt.Errorf("error marshaling outdoor conditions result: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// firstForecastPoint returns the first available forecast point, preferring a
+// daily summary (every provider's interface guarantees at most day-granularity
+// there) and falling back to the first hourly point for providers that only
+// return a timeseries.
+func firstForecastPoint(forecast *Forecast) *ForecastPoint {
+	if len(forecast.Daily) > 0 {
+		return &forecast.Daily[0]
+	}
+	if len(forecast.Hourly) > 0 {
+		return &forecast.Hourly[0]
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// stubSevereProvider returns a fixed forecast so AssessOutdoorConditions can
+// be tested without a live HTTP call, parameterized on the Severe bit each
+// real provider normalizes from its own condition scheme.
+type stubSevereProvider struct {
+	name   string
+	severe bool
+}
+
+func (p *stubSevereProvider) Name() string { return p.name }
+
+func (p *stubSevereProvider) Current(ctx context.Context, lat, lon float64) (*Observation, error) {
+	return &Observation{Severe: p.severe}, nil
+}
+
+func (p *stubSevereProvider) Forecast(ctx context.Context, lat, lon float64, days int) (*Forecast, error) {
+	return &Forecast{Daily: []ForecastPoint{{
+		TemperatureC: 20,
+		Severe:       p.severe,
+	}}}, nil
+}
+
+// TestAssessOutdoorConditionsAppliesSeverityAcrossProviders is a regression
+// test for the cross-provider weather-code bug: the heavy-rain/thunderstorm
+// penalty must fire off the normalized Severe field, not a raw weather code
+// from a single provider's scheme, so it applies identically no matter which
+// WeatherProvider answered the forecast.
+func TestAssessOutdoorConditionsAppliesSeverityAcrossProviders(t *testing.T) {
+	tests := []struct {
+		name   string
+		severe bool
+	}{
+		{name: "open-meteo-like", severe: true},
+		{name: "openweathermap-like", severe: true},
+		{name: "metno-like", severe: true},
+		{name: "clear", severe: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &stubSevereProvider{name: tt.name, severe: tt.severe}
+			RegisterWeatherProvider(provider)
+
+			body, err := AssessOutdoorConditions(AssessOutdoorConditionsParams{
+				Latitude: 1, Longitude: 1, Activity: "hiking", Provider: tt.name,
+			})
+			if err != nil {
+				t.Fatalf("AssessOutdoorConditions returned error: %v", err)
+			}
+
+			var result AssessOutdoorConditionsResult
+			if err := json.Unmarshal([]byte(body), &result); err != nil {
+				t.Fatalf("failed to unmarshal result: %v", err)
+			}
+
+			if tt.severe && result.Score > 60 {
+				t.Errorf("severe conditions scored %d, want a heavily penalized score", result.Score)
+			}
+			if !tt.severe && result.Score <= 60 {
+				t.Errorf("clear conditions scored %d, want a high score", result.Score)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	openai "github.com/sashabaranov/go-openai"
+	"golang.org/x/sync/errgroup"
+)
+
+// Handler processes one decoded tool call's arguments and returns the string
+// result to hand back to the LLM as a tool message.
+type Handler[T any] func(ctx context.Context, args T) (string, error)
+
+// Registry maps tool names to the handler that services them. Register each
+// tool once at startup with Register, then call Dispatch from the agent loop;
+// new tools plug in with a single Register call and no change to Dispatch.
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[string]func(ctx context.Context, rawArgs string) (string, error)
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string]func(ctx context.Context, rawArgs string) (string, error))}
+}
+
+// Register adds def's tool to r under its Function.Name. Each call's raw JSON
+// arguments are unmarshaled into T before handler runs. Register is a free
+// function, not a method, because Go does not allow type parameters on methods.
+func Register[T any](r *Registry, def openai.Tool, handler Handler[T]) {
+	name := def.Function.Name
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[name] = func(ctx context.Context, rawArgs string) (string, error) {
+		var args T
+		if err := json.Unmarshal([]byte(rawArgs), &args); err != nil {
+			return "", fmt.Errorf("invalid arguments for tool %q: %w", name, err)
+		}
+		return handler(ctx, args)
+	}
+}
+
+// Dispatch runs every call concurrently via an errgroup and returns one tool
+// message per call, in the same order the calls were given in. A handler
+// error is reported as the tool message's content rather than failing the
+// whole batch, so one bad tool call does not sink the others.
+func (r *Registry) Dispatch(ctx context.Context, calls []openai.ToolCall) []openai.ChatCompletionMessage {
+	results := make([]openai.ChatCompletionMessage, len(calls))
+
+	g, ctx := errgroup.WithContext(ctx)
+	for i, call := range calls {
+		i, call := i, call
+		g.Go(func() error {
+			content, err := r.run(ctx, call)
+			if err != nil {
+				content = fmt.Sprintf("error: %s", err.Error())
+			}
+			results[i] = openai.ChatCompletionMessage{
+				Role:       openai.ChatMessageRoleTool,
+				Content:    content,
+				Name:       call.Function.Name,
+				ToolCallID: call.ID,
+			}
+			return nil
+		})
+	}
+	_ = g.Wait() // every error is captured per-call above; nothing to propagate
+
+	return results
+}
+
+func (r *Registry) run(ctx context.Context, call openai.ToolCall) (string, error) {
+	r.mu.RLock()
+	handler, ok := r.handlers[call.Function.Name]
+	r.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("no handler registered for tool %q", call.Function.Name)
+	}
+	return handler(ctx, call.Function.Arguments)
+}
@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+type dispatchArgs struct {
+	N int `json:"n"`
+}
+
+// TestRegistryDispatchPreservesCallOrder runs several calls that finish in a
+// randomized, non-FIFO order and checks the returned tool messages still line
+// up with the input calls by position and ToolCallID.
+func TestRegistryDispatchPreservesCallOrder(t *testing.T) {
+	r := NewRegistry()
+	def := openai.Tool{Type: "function", Function: &openai.FunctionDefinition{Name: "Echo"}}
+	Register(r, def, func(ctx context.Context, args dispatchArgs) (string, error) {
+		// Sleep in reverse order of N so the fastest call is the one
+		// Dispatch received last, exercising out-of-order completion.
+		time.Sleep(time.Duration(10-args.N) * time.Millisecond)
+		return fmt.Sprintf("result-%d", args.N), nil
+	})
+
+	calls := make([]openai.ToolCall, 10)
+	for i := range calls {
+		calls[i] = openai.ToolCall{
+			ID: fmt.Sprintf("call-%d", i),
+			Function: openai.FunctionCall{
+				Name:      "Echo",
+				Arguments: fmt.Sprintf(`{"n": %d}`, i),
+			},
+		}
+	}
+	rand.Shuffle(len(calls), func(i, j int) { calls[i], calls[j] = calls[j], calls[i] })
+
+	results := r.Dispatch(context.Background(), calls)
+
+	if len(results) != len(calls) {
+		t.Fatalf("got %d results, want %d", len(results), len(calls))
+	}
+	for i, call := range calls {
+		if results[i].ToolCallID != call.ID {
+			t.Errorf("result[%d].ToolCallID = %q, want %q", i, results[i].ToolCallID, call.ID)
+		}
+		var args dispatchArgs
+		fmt.Sscanf(call.Function.Arguments, `{"n": %d}`, &args.N)
+		want := fmt.Sprintf("result-%d", args.N)
+		if results[i].Content != want {
+			t.Errorf("result[%d].Content = %q, want %q", i, results[i].Content, want)
+		}
+	}
+}
+
+func TestRegistryDispatchReportsHandlerErrorAsContent(t *testing.T) {
+	r := NewRegistry()
+	def := openai.Tool{Type: "function", Function: &openai.FunctionDefinition{Name: "Fails"}}
+	Register(r, def, func(ctx context.Context, args dispatchArgs) (string, error) {
+		return "", fmt.Errorf("boom")
+	})
+
+	calls := []openai.ToolCall{{ID: "call-0", Function: openai.FunctionCall{Name: "Fails", Arguments: `{}`}}}
+	results := r.Dispatch(context.Background(), calls)
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if results[0].Content != "error: boom" {
+		t.Errorf("Content = %q, want %q", results[0].Content, "error: boom")
+	}
+}
+
+func TestRegistryDispatchUnknownTool(t *testing.T) {
+	r := NewRegistry()
+	calls := []openai.ToolCall{{ID: "call-0", Function: openai.FunctionCall{Name: "Missing", Arguments: `{}`}}}
+	results := r.Dispatch(context.Background(), calls)
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if results[0].Content == "" {
+		t.Error("expected an error message for an unregistered tool, got empty content")
+	}
+}
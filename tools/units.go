@@ -0,0 +1,47 @@
+package tools
+
+// The weather tools report metric values (Celsius, km/h, mm) internally since
+// that is what every WeatherProvider returns; these helpers convert to
+// imperial units at the formatting layer when the caller asks for them.
+
+func convertTemperature(celsius float64, units string) float64 {
+	if units == "imperial" {
+		return celsius*9/5 + 32
+	}
+	return celsius
+}
+
+func temperatureUnitLabel(units string) string {
+	if units == "imperial" {
+		return "°F"
+	}
+	return "°C"
+}
+
+func convertSpeed(kmh float64, units string) float64 {
+	if units == "imperial" {
+		return kmh / 1.60934
+	}
+	return kmh
+}
+
+func speedUnitLabel(units string) string {
+	if units == "imperial" {
+		return "mph"
+	}
+	return "km/h"
+}
+
+func convertPrecipitation(mm float64, units string) float64 {
+	if units == "imperial" {
+		return mm / 25.4
+	}
+	return mm
+}
+
+func precipitationUnitLabel(units string) string {
+	if units == "imperial" {
+		return "in"
+	}
+	return "mm"
+}
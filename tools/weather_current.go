@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// GetCurrentWeatherToolDefine defines the OpenAI tool for just the current
+// observation at a location, dispatched through the WeatherProvider registry.
+var GetCurrentWeatherToolDefine = openai.Tool{
+	Type: "function",
+	Function: &openai.FunctionDefinition{
+		Name: "GetCurrentWeather",
+		Description: `
+		Use this tool to get the current weather observation for a specific location.
+		Example:
+			"What's the weather in Shenzhen right now?"
+		Then Action Input is: {"latitude": 22.547, "longitude": 114.058}
+		`,
+		Parameters: `{
+			"type": "object",
+			"properties": {
+				"latitude": {
+					"type": "number",
+					"description": "Latitude coordinate of the location"
+				},
+				"longitude": {
+					"type": "number",
+					"description": "Longitude coordinate of the location"
+				},
+				"units": {
+					"type": "string",
+					"description": "Unit system for the reported values: 'metric' (default) or 'imperial'"
+				},
+				"provider": {
+					"type": "string",
+					"description": "Weather backend to use: 'open-meteo' (default), 'openweathermap', or 'metno'"
+				}
+			},
+			"required": ["latitude", "longitude"]
+		}`,
+	},
+}
+
+// CurrentWeatherParams contains parameters for the GetCurrentWeather tool.
+type CurrentWeatherParams struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Units     string  `json:"units,omitempty"`
+	Provider  string  `json:"provider,omitempty"`
+}
+
+// GetCurrentWeather fetches and formats just the current observation for a
+// location, via whichever WeatherProvider is named by Provider (or the
+// default if empty).
+func GetCurrentWeather(params CurrentWeatherParams) (string, error) {
+	provider, err := weatherProvider(params.Provider)
+	if err != nil {
+		return "", err
+	}
+
+	obs, err := provider.Current(context.Background(), params.Latitude, params.Longitude)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current weather: %w", err)
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Weather provider: %s\n", provider.Name()))
+	result.WriteString("Current Weather:\n")
+	result.WriteString(fmt.Sprintf("Time: %s\n", obs.Time))
+	result.WriteString(fmt.Sprintf("Temperature: %.1f%s\n", convertTemperature(obs.TemperatureC, params.Units), temperatureUnitLabel(params.Units)))
+	if obs.RelativeHumidity != 0 {
+		result.WriteString(fmt.Sprintf("Humidity: %.1f%%\n", obs.RelativeHumidity))
+	}
+	if obs.WindSpeedKmh != 0 {
+		result.WriteString(fmt.Sprintf("Wind Speed: %.1f %s\n", convertSpeed(obs.WindSpeedKmh, params.Units), speedUnitLabel(params.Units)))
+	}
+	if obs.Description != "" {
+		result.WriteString(fmt.Sprintf("Conditions: %s\n", obs.Description))
+	}
+
+	return result.String(), nil
+}
@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// GetWeatherForecastToolDefine defines the OpenAI tool for a multi-day
+// forecast at a location, dispatched through the WeatherProvider registry.
+var GetWeatherForecastToolDefine = openai.Tool{
+	Type: "function",
+	Function: &openai.FunctionDefinition{
+		Name: "GetWeatherForecast",
+		Description: `
+		Use this tool to get a daily (and optionally hourly) forecast for a specific location.
+		Example:
+			"What's the weather forecast for Shenzhen over the next 3 days?"
+		Then Action Input is: {"latitude": 22.547, "longitude": 114.058, "days": 3}
+		`,
+		Parameters: `{
+			"type": "object",
+			"properties": {
+				"latitude": {
+					"type": "number",
+					"description": "Latitude coordinate of the location"
+				},
+				"longitude": {
+					"type": "number",
+					"description": "Longitude coordinate of the location"
+				},
+				"days": {
+					"type": "integer",
+					"description": "Number of days the forecast should cover (provider-dependent default if omitted)"
+				},
+				"hourly_resolution": {
+					"type": "boolean",
+					"description": "Whether to also include an hourly breakdown alongside the daily summary"
+				},
+				"units": {
+					"type": "string",
+					"description": "Unit system for the reported values: 'metric' (default) or 'imperial'"
+				},
+				"provider": {
+					"type": "string",
+					"description": "Weather backend to use: 'open-meteo' (default), 'openweathermap', or 'metno'"
+				}
+			},
+			"required": ["latitude", "longitude"]
+		}`,
+	},
+}
+
+// ForecastWeatherParams contains parameters for the GetWeatherForecast tool.
+type ForecastWeatherParams struct {
+	Latitude         float64 `json:"latitude"`
+	Longitude        float64 `json:"longitude"`
+	Days             int     `json:"days,omitempty"`
+	HourlyResolution bool    `json:"hourly_resolution,omitempty"`
+	Units            string  `json:"units,omitempty"`
+	Provider         string  `json:"provider,omitempty"`
+}
+
+// GetWeatherForecast fetches and formats a compact daily (and optionally
+// hourly) forecast for a location, via whichever WeatherProvider is named by
+// Provider (or the default if empty).
+func GetWeatherForecast(params ForecastWeatherParams) (string, error) {
+	provider, err := weatherProvider(params.Provider)
+	if err != nil {
+		return "", err
+	}
+
+	forecast, err := provider.Forecast(context.Background(), params.Latitude, params.Longitude, params.Days)
+	if err != nil {
+		return "", fmt.Errorf("failed to get weather forecast: %w", err)
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Weather provider: %s\n", provider.Name()))
+
+	if len(forecast.Daily) > 0 {
+		result.WriteString("Daily Forecast:\n")
+		for _, point := range forecast.Daily {
+			writeForecastPoint(&result, point, true, params.Units)
+		}
+	}
+
+	// Fall back to hourly points when the provider has no daily aggregation
+	// (OpenWeatherMap and MET Norway only return a timeseries), or when the
+	// caller explicitly asked for hourly detail alongside the daily summary.
+	if params.HourlyResolution || len(forecast.Daily) == 0 {
+		limit := len(forecast.Hourly)
+		if limit > 24 {
+			limit = 24
+		}
+		if limit > 0 {
+			result.WriteString("\nHourly Forecast:\n")
+			for _, point := range forecast.Hourly[:limit] {
+				writeForecastPoint(&result, point, false, params.Units)
+			}
+		}
+	}
+
+	return result.String(), nil
+}
+
+// writeForecastPoint formats a single hourly or daily ForecastPoint into b,
+// converting its metric values to units ("metric" or "imperial").
+func writeForecastPoint(b *strings.Builder, point ForecastPoint, daily bool, units string) {
+	label := "Time"
+	if daily {
+		label = "Date"
+	}
+	b.WriteString(fmt.Sprintf("%s: %s\n", label, point.Time))
+
+	if daily {
+		if point.TemperatureMaxC != 0 {
+			b.WriteString(fmt.Sprintf("  Max Temperature: %.1f%s\n", convertTemperature(point.TemperatureMaxC, units), temperatureUnitLabel(units)))
+		}
+		if point.TemperatureMinC != 0 {
+			b.WriteString(fmt.Sprintf("  Min Temperature: %.1f%s\n", convertTemperature(point.TemperatureMinC, units), temperatureUnitLabel(units)))
+		}
+	} else if point.TemperatureC != 0 {
+		b.WriteString(fmt.Sprintf("  Temperature: %.1f%s\n", convertTemperature(point.TemperatureC, units), temperatureUnitLabel(units)))
+	}
+
+	if point.RelativeHumidity != 0 {
+		b.WriteString(fmt.Sprintf("  Humidity: %.1f%%\n", point.RelativeHumidity))
+	}
+	if point.WindSpeedKmh != 0 {
+		b.WriteString(fmt.Sprintf("  Wind Speed: %.1f %s\n", convertSpeed(point.WindSpeedKmh, units), speedUnitLabel(units)))
+	}
+	if point.PrecipitationMm != 0 {
+		b.WriteString(fmt.Sprintf("  Precipitation: %.1f %s\n", convertPrecipitation(point.PrecipitationMm, units), precipitationUnitLabel(units)))
+	}
+	if point.Description != "" {
+		b.WriteString(fmt.Sprintf("  Conditions: %s\n", point.Description))
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,224 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+func init() {
+	RegisterWeatherProvider(&MetNorwayProvider{})
+}
+
+var metNorwayLocationforecastURL = "https://api.met.no/weatherapi/locationforecast/2.0/compact"
+
+// metNorwayUserAgent identifies this application to MET Norway's API, as
+// required by their terms of service (https://api.met.no/doc/TermsOfService).
+const metNorwayUserAgent = "function-calling-weather-tool/1.0 (https://github.com/JoneyXiao/function-calling)"
+
+// MetNorwayProvider talks to MET Norway's Locationforecast 2.0 API. Per MET's
+// terms, requests must carry a descriptive User-Agent and should reuse the
+// ETag/Last-Modified headers of the previous response to avoid needless
+// re-downloads; this provider caches the last response per coordinate pair and
+// sends If-None-Match/If-Modified-Since on subsequent calls.
+type MetNorwayProvider struct {
+	mu    sync.Mutex
+	cache map[string]*metNorwayCacheEntry
+}
+
+type metNorwayCacheEntry struct {
+	etag         string
+	lastModified string
+	data         metNorwayResponse
+}
+
+func (p *MetNorwayProvider) Name() string { return "metno" }
+
+// metNorwaySevereSymbolKeywords are substrings of MET Norway's symbol_code
+// (https://api.met.no/weatherapi/weathericon/2.0/documentation) that denote
+// weather severe enough to make an outdoor activity unsafe - thunder, heavy
+// rain, heavy snow, and heavy sleet. MET Norway has no numeric weather code,
+// so this is the metno side of the normalized Observation/ForecastPoint
+// Severe field.
+var metNorwaySevereSymbolKeywords = []string{"thunder", "heavyrain", "heavysnow", "heavysleet"}
+
+// isMetNorwaySevere reports whether symbolCode names one of
+// metNorwaySevereSymbolKeywords, e.g. "heavyrainandthunder" or
+// "lightrainshowersandthunder".
+func isMetNorwaySevere(symbolCode string) bool {
+	lower := strings.ToLower(symbolCode)
+	for _, keyword := range metNorwaySevereSymbolKeywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+type metNorwayResponse struct {
+	Properties struct {
+		Timeseries []struct {
+			Time string `json:"time"`
+			Data struct {
+				Instant struct {
+					Details struct {
+						AirTemperature   float64 `json:"air_temperature"`
+						RelativeHumidity float64 `json:"relative_humidity"`
+						WindSpeed        float64 `json:"wind_speed"`
+					} `json:"details"`
+				} `json:"instant"`
+				Next1Hours *struct {
+					Summary struct {
+						SymbolCode string `json:"symbol_code"`
+					} `json:"summary"`
+					Details struct {
+						PrecipitationAmount float64 `json:"precipitation_amount"`
+					} `json:"details"`
+				} `json:"next_1_hours,omitempty"`
+			} `json:"data"`
+		} `json:"timeseries"`
+	} `json:"properties"`
+}
+
+// fetch returns the cached response for (lat, lon) if MET Norway confirms it is
+// still fresh (HTTP 304), otherwise it performs a fresh request and updates the
+// cache with the new ETag/Last-Modified.
+func (p *MetNorwayProvider) fetch(ctx context.Context, lat, lon float64) (*metNorwayResponse, error) {
+	key := fmt.Sprintf("%.4f,%.4f", lat, lon)
+
+	p.mu.Lock()
+	if p.cache == nil {
+		p.cache = make(map[string]*metNorwayCacheEntry)
+	}
+	cached := p.cache[key]
+	p.mu.Unlock()
+
+	q := url.Values{}
+	q.Add("lat", fmt.Sprintf("%.4f", lat))
+	q.Add("lon", fmt.Sprintf("%.4f", lon))
+	fullURL := fmt.Sprintf("%s?%s", metNorwayLocationforecastURL, q.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	req.Header.Set("User-Agent", metNorwayUserAgent)
+	if cached != nil {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return &cached.data, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error: %s - %s", resp.Status, string(body))
+	}
+
+	var data metNorwayResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("error parsing JSON response: %w", err)
+	}
+
+	entry := &metNorwayCacheEntry{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		data:         data,
+	}
+	p.mu.Lock()
+	p.cache[key] = entry
+	p.mu.Unlock()
+
+	return &data, nil
+}
+
+func (p *MetNorwayProvider) Current(ctx context.Context, lat, lon float64) (*Observation, error) {
+	data, err := p.fetch(ctx, lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("metno: %w", err)
+	}
+	if len(data.Properties.Timeseries) == 0 {
+		return nil, fmt.Errorf("metno: no timeseries data returned")
+	}
+
+	entry := data.Properties.Timeseries[0]
+	obs := &Observation{
+		Time:             entry.Time,
+		TemperatureC:     entry.Data.Instant.Details.AirTemperature,
+		RelativeHumidity: entry.Data.Instant.Details.RelativeHumidity,
+		WindSpeedKmh:     entry.Data.Instant.Details.WindSpeed * 3.6,
+	}
+	if entry.Data.Next1Hours != nil {
+		obs.Description = entry.Data.Next1Hours.Summary.SymbolCode
+		obs.Severe = isMetNorwaySevere(obs.Description)
+	}
+	return obs, nil
+}
+
+func (p *MetNorwayProvider) Forecast(ctx context.Context, lat, lon float64, days int) (*Forecast, error) {
+	if days <= 0 {
+		days = 7
+	}
+
+	data, err := p.fetch(ctx, lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("metno: %w", err)
+	}
+
+	cutoff := time.Time{}
+	forecast := &Forecast{}
+	for i, entry := range data.Properties.Timeseries {
+		t, err := time.Parse(time.RFC3339, entry.Time)
+		if err == nil {
+			if cutoff.IsZero() {
+				cutoff = t.AddDate(0, 0, days)
+			}
+			if t.After(cutoff) {
+				break
+			}
+		}
+
+		point := ForecastPoint{
+			Time:             entry.Time,
+			TemperatureC:     entry.Data.Instant.Details.AirTemperature,
+			RelativeHumidity: entry.Data.Instant.Details.RelativeHumidity,
+			WindSpeedKmh:     entry.Data.Instant.Details.WindSpeed * 3.6,
+		}
+		if entry.Data.Next1Hours != nil {
+			point.Description = entry.Data.Next1Hours.Summary.SymbolCode
+			point.PrecipitationMm = entry.Data.Next1Hours.Details.PrecipitationAmount
+			point.Severe = isMetNorwaySevere(point.Description)
+		}
+		forecast.Hourly = append(forecast.Hourly, point)
+
+		// Locationforecast returns a long, unbounded hourly timeseries; fall
+		// back to an index cap in case timestamps fail to parse.
+		if i >= days*24 {
+			break
+		}
+	}
+
+	return forecast, nil
+}
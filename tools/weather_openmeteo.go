@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+func init() {
+	RegisterWeatherProvider(&OpenMeteoProvider{})
+}
+
+var openMeteoForecastURL = "https://api.open-meteo.com/v1/forecast"
+
+// severeWMOWeatherCodes are the WMO weather codes (shared by Open-Meteo) for
+// conditions - heavy rain, heavy snow, violent showers, thunderstorms - severe
+// enough that an outdoor activity is unsafe regardless of temperature or wind.
+// This is the Open-Meteo side of the normalized Observation/ForecastPoint
+// Severe field; other providers classify their own condition schemes.
+var severeWMOWeatherCodes = map[int]bool{
+	65: true, // Heavy rain
+	75: true, // Heavy snow fall
+	82: true, // Violent rain showers
+	95: true, // Thunderstorm
+	96: true, // Thunderstorm with slight hail
+	99: true, // Thunderstorm with heavy hail
+}
+
+// weatherCodeToDescription maps WMO weather codes (shared by Open-Meteo and MET
+// Norway's "symbol_code" equivalents) to human-readable descriptions.
+var weatherCodeToDescription = map[int]string{
+	0:  "Clear sky",
+	1:  "Mainly clear",
+	2:  "Partly cloudy",
+	3:  "Overcast",
+	45: "Fog",
+	48: "Depositing rime fog",
+	51: "Light drizzle",
+	53: "Moderate drizzle",
+	55: "Dense drizzle",
+	61: "Slight rain",
+	63: "Moderate rain",
+	65: "Heavy rain",
+	71: "Slight snow fall",
+	73: "Moderate snow fall",
+	75: "Heavy snow fall",
+	80: "Slight rain showers",
+	81: "Moderate rain showers",
+	82: "Violent rain showers",
+	95: "Thunderstorm",
+	96: "Thunderstorm with slight hail",
+	99: "Thunderstorm with heavy hail",
+}
+
+// OpenMeteoProvider talks to the free, no-API-key Open-Meteo forecast API. It is
+// the default WeatherProvider.
+type OpenMeteoProvider struct{}
+
+func (p *OpenMeteoProvider) Name() string { return "open-meteo" }
+
+type openMeteoResponse struct {
+	Current struct {
+		Time               string  `json:"time"`
+		Temperature2m      float64 `json:"temperature_2m"`
+		RelativeHumidity2m float64 `json:"relative_humidity_2m"`
+		WindSpeed10m       float64 `json:"wind_speed_10m"`
+		WeatherCode        int     `json:"weather_code"`
+	} `json:"current"`
+	Hourly struct {
+		Time               []string  `json:"time"`
+		Temperature2m      []float64 `json:"temperature_2m"`
+		RelativeHumidity2m []float64 `json:"relative_humidity_2m"`
+		WindSpeed10m       []float64 `json:"wind_speed_10m"`
+		WeatherCode        []int     `json:"weather_code"`
+	} `json:"hourly"`
+	Daily struct {
+		Time             []string  `json:"time"`
+		Temperature2mMax []float64 `json:"temperature_2m_max"`
+		Temperature2mMin []float64 `json:"temperature_2m_min"`
+		PrecipitationSum []float64 `json:"precipitation_sum"`
+		WeatherCode      []int     `json:"weather_code"`
+	} `json:"daily"`
+}
+
+func (p *OpenMeteoProvider) Current(ctx context.Context, lat, lon float64) (*Observation, error) {
+	q := url.Values{}
+	q.Add("latitude", fmt.Sprintf("%.6f", lat))
+	q.Add("longitude", fmt.Sprintf("%.6f", lon))
+	q.Add("current", "temperature_2m,relative_humidity_2m,weather_code,wind_speed_10m")
+	q.Add("timezone", "auto")
+
+	var data openMeteoResponse
+	if err := getJSON(ctx, fmt.Sprintf("%s?%s", openMeteoForecastURL, q.Encode()), nil, &data); err != nil {
+		return nil, fmt.Errorf("open-meteo: %w", err)
+	}
+
+	return &Observation{
+		Time:             data.Current.Time,
+		TemperatureC:     data.Current.Temperature2m,
+		RelativeHumidity: data.Current.RelativeHumidity2m,
+		WindSpeedKmh:     data.Current.WindSpeed10m,
+		WeatherCode:      data.Current.WeatherCode,
+		Description:      weatherCodeToDescription[data.Current.WeatherCode],
+		Severe:           severeWMOWeatherCodes[data.Current.WeatherCode],
+	}, nil
+}
+
+func (p *OpenMeteoProvider) Forecast(ctx context.Context, lat, lon float64, days int) (*Forecast, error) {
+	if days <= 0 {
+		days = 7
+	}
+
+	q := url.Values{}
+	q.Add("latitude", fmt.Sprintf("%.6f", lat))
+	q.Add("longitude", fmt.Sprintf("%.6f", lon))
+	q.Add("hourly", "temperature_2m,relative_humidity_2m,wind_speed_10m,weather_code")
+	q.Add("daily", "temperature_2m_max,temperature_2m_min,precipitation_sum,weather_code")
+	q.Add("forecast_days", fmt.Sprintf("%d", days))
+	q.Add("timezone", "auto")
+
+	var data openMeteoResponse
+	if err := getJSON(ctx, fmt.Sprintf("%s?%s", openMeteoForecastURL, q.Encode()), nil, &data); err != nil {
+		return nil, fmt.Errorf("open-meteo: %w", err)
+	}
+
+	forecast := &Forecast{}
+	for i := range data.Hourly.Time {
+		point := ForecastPoint{Time: data.Hourly.Time[i]}
+		if i < len(data.Hourly.Temperature2m) {
+			point.TemperatureC = data.Hourly.Temperature2m[i]
+		}
+		if i < len(data.Hourly.RelativeHumidity2m) {
+			point.RelativeHumidity = data.Hourly.RelativeHumidity2m[i]
+		}
+		if i < len(data.Hourly.WindSpeed10m) {
+			point.WindSpeedKmh = data.Hourly.WindSpeed10m[i]
+		}
+		if i < len(data.Hourly.WeatherCode) {
+			point.WeatherCode = data.Hourly.WeatherCode[i]
+			point.Description = weatherCodeToDescription[point.WeatherCode]
+			point.Severe = severeWMOWeatherCodes[point.WeatherCode]
+		}
+		forecast.Hourly = append(forecast.Hourly, point)
+	}
+
+	for i := range data.Daily.Time {
+		point := ForecastPoint{Time: data.Daily.Time[i]}
+		if i < len(data.Daily.Temperature2mMax) {
+			point.TemperatureMaxC = data.Daily.Temperature2mMax[i]
+		}
+		if i < len(data.Daily.Temperature2mMin) {
+			point.TemperatureMinC = data.Daily.Temperature2mMin[i]
+		}
+		if i < len(data.Daily.PrecipitationSum) {
+			point.PrecipitationMm = data.Daily.PrecipitationSum[i]
+		}
+		if i < len(data.Daily.WeatherCode) {
+			point.WeatherCode = data.Daily.WeatherCode[i]
+			point.Description = weatherCodeToDescription[point.WeatherCode]
+			point.Severe = severeWMOWeatherCodes[point.WeatherCode]
+		}
+		forecast.Daily = append(forecast.Daily, point)
+	}
+
+	return forecast, nil
+}
+
+// getJSON performs a GET request with optional extra headers and decodes the
+// JSON response body into out. A non-2xx status is returned as an error
+// carrying the response body for diagnostics.
+func getJSON(ctx context.Context, fullURL string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error: %s - %s", resp.Status, string(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("error parsing JSON response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+)
+
+func init() {
+	RegisterWeatherProvider(&OpenWeatherMapProvider{})
+}
+
+var (
+	owmCurrentURL  = "https://api.openweathermap.org/data/2.5/weather"
+	owmForecastURL = "https://api.openweathermap.org/data/2.5/forecast"
+)
+
+// OpenWeatherMapProvider talks to the OpenWeatherMap current weather and 5-day/3-hour
+// forecast APIs. It reads its API key from OPENWEATHERMAP_API_KEY, and defaults to
+// metric units and English descriptions; set Units/Lang to override either.
+type OpenWeatherMapProvider struct {
+	Units string // "standard", "metric" (default), or "imperial"
+	Lang  string // OpenWeatherMap language code, defaults to "en"
+}
+
+func (p *OpenWeatherMapProvider) Name() string { return "openweathermap" }
+
+// owmSevereConditionCodes are OpenWeatherMap condition codes
+// (https://openweathermap.org/weather-conditions) for thunderstorms, heavy or
+// violent rain/snow, and extreme weather - the OpenWeatherMap side of the
+// normalized Observation/ForecastPoint Severe field.
+var owmSevereConditionCodes = map[int]bool{
+	502: true, // Heavy intensity rain
+	503: true, // Very heavy rain
+	504: true, // Extreme rain
+	522: true, // Violent rain shower
+	602: true, // Heavy snow
+	622: true, // Heavy shower snow
+}
+
+// isOWMSevere reports whether an OpenWeatherMap condition code id denotes
+// weather severe enough to make an outdoor activity unsafe: any thunderstorm
+// (200-232), the heavy rain/snow codes above, or an extreme event (900-906,
+// tornado through hurricane).
+func isOWMSevere(id int) bool {
+	if id >= 200 && id < 233 {
+		return true
+	}
+	if id >= 900 && id <= 906 {
+		return true
+	}
+	return owmSevereConditionCodes[id]
+}
+
+func (p *OpenWeatherMapProvider) units() string {
+	if p.Units != "" {
+		return p.Units
+	}
+	return "metric"
+}
+
+func (p *OpenWeatherMapProvider) lang() string {
+	if p.Lang != "" {
+		return p.Lang
+	}
+	return "en"
+}
+
+func (p *OpenWeatherMapProvider) query(lat, lon float64) (url.Values, error) {
+	apiKey := os.Getenv("OPENWEATHERMAP_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("OPENWEATHERMAP_API_KEY is not set")
+	}
+
+	q := url.Values{}
+	q.Add("lat", fmt.Sprintf("%.6f", lat))
+	q.Add("lon", fmt.Sprintf("%.6f", lon))
+	q.Add("appid", apiKey)
+	q.Add("units", p.units())
+	q.Add("lang", p.lang())
+	return q, nil
+}
+
+type owmWeather struct {
+	Main struct {
+		Temp     float64 `json:"temp"`
+		Humidity float64 `json:"humidity"`
+	} `json:"main"`
+	Wind struct {
+		Speed float64 `json:"speed"`
+	} `json:"wind"`
+	Weather []struct {
+		ID          int    `json:"id"`
+		Description string `json:"description"`
+	} `json:"weather"`
+	Dt int64 `json:"dt"`
+}
+
+type owmForecastResponse struct {
+	List []struct {
+		Dt    int64  `json:"dt"`
+		DtTxt string `json:"dt_txt"`
+		Main  struct {
+			Temp     float64 `json:"temp"`
+			TempMin  float64 `json:"temp_min"`
+			TempMax  float64 `json:"temp_max"`
+			Humidity float64 `json:"humidity"`
+		} `json:"main"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+		} `json:"wind"`
+		Weather []struct {
+			ID          int    `json:"id"`
+			Description string `json:"description"`
+		} `json:"weather"`
+		Rain map[string]float64 `json:"rain"`
+	} `json:"list"`
+}
+
+func (p *OpenWeatherMapProvider) Current(ctx context.Context, lat, lon float64) (*Observation, error) {
+	q, err := p.query(lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("openweathermap: %w", err)
+	}
+
+	var data owmWeather
+	if err := getJSON(ctx, fmt.Sprintf("%s?%s", owmCurrentURL, q.Encode()), nil, &data); err != nil {
+		return nil, fmt.Errorf("openweathermap: %w", err)
+	}
+
+	obs := &Observation{
+		Time:             fmt.Sprintf("%d", data.Dt),
+		TemperatureC:     data.Main.Temp,
+		RelativeHumidity: data.Main.Humidity,
+		// OpenWeatherMap reports wind speed in m/s for metric/standard units.
+		WindSpeedKmh: data.Wind.Speed * 3.6,
+	}
+	if len(data.Weather) > 0 {
+		obs.WeatherCode = data.Weather[0].ID
+		obs.Description = data.Weather[0].Description
+		obs.Severe = isOWMSevere(obs.WeatherCode)
+	}
+	return obs, nil
+}
+
+func (p *OpenWeatherMapProvider) Forecast(ctx context.Context, lat, lon float64, days int) (*Forecast, error) {
+	if days <= 0 {
+		days = 5
+	}
+
+	q, err := p.query(lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("openweathermap: %w", err)
+	}
+
+	var data owmForecastResponse
+	if err := getJSON(ctx, fmt.Sprintf("%s?%s", owmForecastURL, q.Encode()), nil, &data); err != nil {
+		return nil, fmt.Errorf("openweathermap: %w", err)
+	}
+
+	// The forecast API returns 3-hourly entries for up to 5 days; cap to the
+	// requested window instead of aggregating into daily buckets.
+	limit := days * 8
+	if limit > len(data.List) {
+		limit = len(data.List)
+	}
+
+	forecast := &Forecast{}
+	for i := 0; i < limit; i++ {
+		entry := data.List[i]
+		point := ForecastPoint{
+			Time:             entry.DtTxt,
+			TemperatureC:     entry.Main.Temp,
+			RelativeHumidity: entry.Main.Humidity,
+			WindSpeedKmh:     entry.Wind.Speed * 3.6,
+			PrecipitationMm:  entry.Rain["3h"],
+		}
+		if len(entry.Weather) > 0 {
+			point.WeatherCode = entry.Weather[0].ID
+			point.Description = entry.Weather[0].Description
+			point.Severe = isOWMSevere(point.WeatherCode)
+		}
+		forecast.Hourly = append(forecast.Hourly, point)
+	}
+
+	return forecast, nil
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// defaultWeatherProvider is used when the caller does not specify one.
+const defaultWeatherProvider = "open-meteo"
+
+// Observation is a single-point-in-time weather reading returned by a WeatherProvider.
+// WeatherCode is provider-specific (each provider documents its own scheme) and
+// is only meant for display; Severe is the normalized, cross-provider signal
+// every WeatherProvider implementation is responsible for setting correctly.
+type Observation struct {
+	Time             string
+	TemperatureC     float64
+	RelativeHumidity float64
+	WindSpeedKmh     float64
+	WeatherCode      int
+	Description      string
+	Severe           bool
+}
+
+// ForecastPoint is one hourly or daily entry within a Forecast. TemperatureMinC/MaxC
+// are only populated for daily points; hourly points use TemperatureC.
+// WeatherCode is provider-specific and display-only; Severe is the normalized,
+// cross-provider signal (heavy rain/snow, thunderstorm, ...) every
+// WeatherProvider implementation is responsible for setting correctly.
+type ForecastPoint struct {
+	Time             string
+	TemperatureC     float64
+	TemperatureMinC  float64
+	TemperatureMaxC  float64
+	RelativeHumidity float64
+	WindSpeedKmh     float64
+	PrecipitationMm  float64
+	WeatherCode      int
+	Description      string
+	Severe           bool
+}
+
+// Forecast bundles the hourly and daily points a WeatherProvider can supply.
+type Forecast struct {
+	Hourly []ForecastPoint
+	Daily  []ForecastPoint
+}
+
+// WeatherProvider is implemented by each weather backend the GetCurrentWeather,
+// GetWeatherForecast, and AssessOutdoorConditions tools can dispatch to.
+// Implementations register themselves with RegisterWeatherProvider, typically
+// from an init func in their own file.
+type WeatherProvider interface {
+	// Name is the registry key used to select this provider (e.g. "open-meteo").
+	Name() string
+	Current(ctx context.Context, lat, lon float64) (*Observation, error)
+	Forecast(ctx context.Context, lat, lon float64, days int) (*Forecast, error)
+}
+
+var (
+	weatherProvidersMu sync.RWMutex
+	weatherProviders   = map[string]WeatherProvider{}
+)
+
+// RegisterWeatherProvider adds a WeatherProvider to the registry under its own
+// Name(), overwriting any provider previously registered under that name. This
+// lets users add their own backend without touching the tool definition.
+func RegisterWeatherProvider(p WeatherProvider) {
+	weatherProvidersMu.Lock()
+	defer weatherProvidersMu.Unlock()
+	weatherProviders[p.Name()] = p
+}
+
+// weatherProvider looks up a registered provider by name, falling back to
+// defaultWeatherProvider when name is empty.
+func weatherProvider(name string) (WeatherProvider, error) {
+	if name == "" {
+		name = defaultWeatherProvider
+	}
+
+	weatherProvidersMu.RLock()
+	defer weatherProvidersMu.RUnlock()
+
+	p, ok := weatherProviders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown weather provider %q", name)
+	}
+	return p, nil
+}